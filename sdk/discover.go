@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+// Source identifies how an Android SDK root directory was determined.
+type Source int
+
+const (
+	// SourceNone indicates that no SDK root could be determined.
+	SourceNone Source = iota
+	// SourceAndroidHome indicates that the SDK root came from ANDROID_HOME.
+	SourceAndroidHome
+	// SourceAndroidSDKRoot indicates that the SDK root came from ANDROID_SDK_ROOT.
+	SourceAndroidSDKRoot
+	// SourceProbe indicates that the SDK root was found by probing well-known install locations.
+	SourceProbe
+)
+
+// sdkMarkers are directories whose presence inside a candidate root indicates an Android SDK install.
+var sdkMarkers = []string{"platform-tools", "cmdline-tools", "tools"}
+
+// DiscoverSDK resolves the Android SDK root directory. ANDROID_HOME and
+// ANDROID_SDK_ROOT (in that order of precedence) are tried first; if neither
+// is set, a prioritized list of well-known install locations is probed, along
+// with the installation a `sdkmanager` or `adb` found on PATH belongs to.
+func DiscoverSDK(env Environment) (string, Source, error) {
+	if env.androidHome != "" {
+		return env.androidHome, SourceAndroidHome, nil
+	}
+
+	if env.androidSDKRoot != "" {
+		return env.androidSDKRoot, SourceAndroidSDKRoot, nil
+	}
+
+	for _, candidate := range probeCandidates(env) {
+		if isSDKRoot(candidate) {
+			return candidate, SourceProbe, nil
+		}
+	}
+
+	return "", SourceNone, errors.New("no Android SDK found: set ANDROID_HOME or ANDROID_SDK_ROOT, or install the SDK at one of the well-known locations")
+}
+
+func probeCandidates(env Environment) []string {
+	var candidates []string
+
+	switch env.goos {
+	case "darwin":
+		if env.homeDir != "" {
+			candidates = append(candidates, filepath.Join(env.homeDir, "Library", "Android", "sdk"))
+		}
+	case "windows":
+		if env.localAppData != "" {
+			candidates = append(candidates, filepath.Join(env.localAppData, "Android", "Sdk"))
+		}
+	default:
+		if env.homeDir != "" {
+			candidates = append(candidates, filepath.Join(env.homeDir, "Android", "Sdk"))
+		}
+	}
+
+	for _, dir := range env.pathDirs {
+		for _, bin := range []string{"sdkmanager", "adb"} {
+			if exist, err := pathutil.IsPathExists(filepath.Join(dir, bin)); err == nil && exist {
+				if root := sdkRootFromToolDir(dir); root != "" {
+					candidates = append(candidates, root)
+				}
+			}
+		}
+	}
+
+	return candidates
+}
+
+// sdkRootFromToolDir walks up from a directory containing a tool binary
+// (e.g. platform-tools, cmdline-tools/<version>/bin, tools/bin) until it
+// finds the enclosing SDK root.
+func sdkRootFromToolDir(toolDir string) string {
+	dir := toolDir
+	for i := 0; i < 4; i++ {
+		if isSDKRoot(dir) {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return ""
+}
+
+func isSDKRoot(dir string) bool {
+	for _, marker := range sdkMarkers {
+		if exist, err := pathutil.IsDirExists(filepath.Join(dir, marker)); err == nil && exist {
+			return true
+		}
+	}
+
+	return false
+}
+
+func currentGOOS() string {
+	return runtime.GOOS
+}
+
+func currentPathDirs() []string {
+	return filepath.SplitList(os.Getenv("PATH"))
+}
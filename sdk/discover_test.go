@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverSDK(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	androidHome := filepath.Join(tmpDir, "android-home")
+	require.NoError(t, os.MkdirAll(androidHome, 0700))
+
+	sdkRoot := filepath.Join(tmpDir, "sdk-root")
+	require.NoError(t, os.MkdirAll(sdkRoot, 0700))
+
+	macSDK := filepath.Join(tmpDir, "mac-home", "Library", "Android", "sdk")
+	require.NoError(t, os.MkdirAll(filepath.Join(macSDK, "platform-tools"), 0700))
+
+	winSDK := filepath.Join(tmpDir, "local-app-data", "Android", "Sdk")
+	require.NoError(t, os.MkdirAll(filepath.Join(winSDK, "platform-tools"), 0700))
+
+	linuxSDK := filepath.Join(tmpDir, "linux-home", "Android", "Sdk")
+	require.NoError(t, os.MkdirAll(filepath.Join(linuxSDK, "cmdline-tools"), 0700))
+
+	pathSDK := filepath.Join(tmpDir, "path-sdk")
+	require.NoError(t, os.MkdirAll(filepath.Join(pathSDK, "platform-tools"), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(pathSDK, "platform-tools", "adb"), 0700))
+
+	cmdlineToolsPathSDK := filepath.Join(tmpDir, "cmdline-tools-path-sdk")
+	cmdlineToolsBinDir := filepath.Join(cmdlineToolsPathSDK, "cmdline-tools", "latest", "bin")
+	require.NoError(t, os.MkdirAll(cmdlineToolsBinDir, 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(cmdlineToolsBinDir, "sdkmanager"), 0700))
+
+	tests := []struct {
+		name       string
+		env        Environment
+		wantRoot   string
+		wantSource Source
+		wantErr    bool
+	}{
+		{
+			name:       "ANDROID_HOME set",
+			env:        Environment{androidHome: androidHome, androidSDKRoot: sdkRoot},
+			wantRoot:   androidHome,
+			wantSource: SourceAndroidHome,
+		},
+		{
+			name:       "ANDROID_SDK_ROOT set",
+			env:        Environment{androidSDKRoot: sdkRoot},
+			wantRoot:   sdkRoot,
+			wantSource: SourceAndroidSDKRoot,
+		},
+		{
+			name:       "probe macOS default location",
+			env:        Environment{homeDir: filepath.Join(tmpDir, "mac-home"), goos: "darwin"},
+			wantRoot:   macSDK,
+			wantSource: SourceProbe,
+		},
+		{
+			name:       "probe Windows default location",
+			env:        Environment{localAppData: filepath.Join(tmpDir, "local-app-data"), goos: "windows"},
+			wantRoot:   winSDK,
+			wantSource: SourceProbe,
+		},
+		{
+			name:       "probe Linux default location",
+			env:        Environment{homeDir: filepath.Join(tmpDir, "linux-home"), goos: "linux"},
+			wantRoot:   linuxSDK,
+			wantSource: SourceProbe,
+		},
+		{
+			name:       "probe via adb on PATH",
+			env:        Environment{goos: "linux", pathDirs: []string{filepath.Join(pathSDK, "platform-tools")}},
+			wantRoot:   pathSDK,
+			wantSource: SourceProbe,
+		},
+		{
+			// sdkmanager sits 4 directories below the SDK root
+			// (cmdline-tools/latest/bin/sdkmanager), exactly at the boundary
+			// of sdkRootFromToolDir's walk-up limit.
+			name:       "probe via sdkmanager on PATH, cmdline-tools/latest/bin",
+			env:        Environment{goos: "linux", pathDirs: []string{cmdlineToolsBinDir}},
+			wantRoot:   cmdlineToolsPathSDK,
+			wantSource: SourceProbe,
+		},
+		{
+			name:    "nothing set, nothing found",
+			env:     Environment{goos: "linux", homeDir: filepath.Join(tmpDir, "no-such-home")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, source, err := DiscoverSDK(tt.env)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRoot, root)
+			require.Equal(t, tt.wantSource, source)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package sdk
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	version "github.com/hashicorp/go-version"
+)
+
+// NDKPlatformRange describes the minimum and maximum API levels an NDK supports,
+// along with any platform aliases it declares.
+type NDKPlatformRange struct {
+	Min     int
+	Max     int
+	Aliases map[string]int
+}
+
+// NDKABI describes a single entry of an NDK's meta/abis.json.
+type NDKABI struct {
+	Arch       string `json:"arch"`
+	Bitness    int    `json:"bitness"`
+	Default    bool   `json:"default"`
+	Deprecated bool   `json:"deprecated"`
+}
+
+// NDKRoot returns the root directory ($ANDROID_HOME/ndk) under which versioned
+// NDK installations live, honoring an explicit ANDROID_NDK_ROOT override and
+// falling back to the deprecated ANDROID_NDK_HOME (with a warning).
+func (model Model) NDKRoot() (string, error) {
+	if ndkRoot := os.Getenv("ANDROID_NDK_ROOT"); ndkRoot != "" {
+		return ndkRoot, nil
+	}
+
+	if ndkHome := os.Getenv("ANDROID_NDK_HOME"); ndkHome != "" {
+		fmt.Fprintln(os.Stderr, "warning: ANDROID_NDK_HOME is deprecated, use ANDROID_NDK_ROOT or ANDROID_HOME/ndk instead")
+		return ndkHome, nil
+	}
+
+	ndkRoot := filepath.Join(model.androidHome, "ndk")
+	if exist, err := pathutil.IsDirExists(ndkRoot); err != nil {
+		return "", err
+	} else if !exist {
+		return "", fmt.Errorf("no NDK installation found at: %s", ndkRoot)
+	}
+
+	return ndkRoot, nil
+}
+
+// LatestNDKDir locates the ndk/<version> directory with the highest semver version.
+func (model Model) LatestNDKDir() (string, error) {
+	ndkRoot, err := model.NDKRoot()
+	if err != nil {
+		return "", err
+	}
+
+	ndkDirs, err := filepath.Glob(filepath.Join(ndkRoot, "*"))
+	if err != nil {
+		return "", err
+	}
+
+	var latestDir string
+	var latestVersion *version.Version
+	for _, dir := range ndkDirs {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+
+		v, err := version.NewVersion(filepath.Base(dir))
+		if err != nil {
+			continue
+		}
+
+		if latestVersion == nil || v.GreaterThan(latestVersion) {
+			latestVersion = v
+			latestDir = dir
+		}
+	}
+
+	if latestDir == "" {
+		return "", errors.New("failed to find latest ndk dir")
+	}
+
+	return latestDir, nil
+}
+
+// NDKRevision parses source.properties of the latest NDK installation and returns
+// the Pkg.Revision value.
+func (model Model) NDKRevision() (string, error) {
+	ndkDir, err := model.LatestNDKDir()
+	if err != nil {
+		return "", err
+	}
+
+	return parseNDKRevision(filepath.Join(ndkDir, "source.properties"))
+}
+
+func parseNDKRevision(sourcePropertiesPth string) (string, error) {
+	f, err := os.Open(sourcePropertiesPth)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		if key == "Pkg.Revision" {
+			return value, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", errors.New("Pkg.Revision not found in source.properties")
+}
+
+// NDKSupportedABIs parses meta/abis.json of the latest NDK installation and
+// returns the ABI names that are not marked deprecated.
+func (model Model) NDKSupportedABIs() ([]string, error) {
+	ndkDir, err := model.LatestNDKDir()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(filepath.Join(ndkDir, "meta", "abis.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var abis map[string]NDKABI
+	if err := json.Unmarshal(b, &abis); err != nil {
+		return nil, err
+	}
+
+	var supported []string
+	for name, abi := range abis {
+		if !abi.Deprecated {
+			supported = append(supported, name)
+		}
+	}
+
+	return supported, nil
+}
+
+// NDKPlatformRange parses meta/platforms.json of the latest NDK installation and
+// returns the supported API-level range and any platform aliases.
+func (model Model) NDKPlatformRange() (NDKPlatformRange, error) {
+	ndkDir, err := model.LatestNDKDir()
+	if err != nil {
+		return NDKPlatformRange{}, err
+	}
+
+	b, err := os.ReadFile(filepath.Join(ndkDir, "meta", "platforms.json"))
+	if err != nil {
+		return NDKPlatformRange{}, err
+	}
+
+	var raw struct {
+		Min     int            `json:"min"`
+		Max     int            `json:"max"`
+		Aliases map[string]int `json:"aliases"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return NDKPlatformRange{}, err
+	}
+
+	return NDKPlatformRange{Min: raw.Min, Max: raw.Max, Aliases: raw.Aliases}, nil
+}
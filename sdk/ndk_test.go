@@ -0,0 +1,132 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/envutil"
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+func stageNDK(t *testing.T, ndkRoot, ndkVersion string) string {
+	ndkDir := filepath.Join(ndkRoot, ndkVersion)
+	require.NoError(t, os.MkdirAll(filepath.Join(ndkDir, "meta"), 0700))
+
+	sourceProperties := "" +
+		"# comment line\n" +
+		"Pkg.Desc = Android NDK\n" +
+		"Pkg.Revision = " + ndkVersion + "\n"
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(ndkDir, "source.properties"), sourceProperties))
+
+	platformsJSON := `{"min": 16, "max": 33, "aliases": {"20": 19}}`
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(ndkDir, "meta", "platforms.json"), platformsJSON))
+
+	abisJSON := `{
+		"arm64-v8a": {"arch": "arm64", "bitness": 64, "default": true, "deprecated": false},
+		"armeabi-v7a": {"arch": "arm", "bitness": 32, "default": true, "deprecated": false},
+		"mips": {"arch": "mips", "bitness": 32, "default": false, "deprecated": true}
+	}`
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(ndkDir, "meta", "abis.json"), abisJSON))
+
+	return ndkDir
+}
+
+func TestLatestNDKDir(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	ndkRoot := filepath.Join(tmpDir, "ndk")
+	for _, ndkVersion := range []string{"21.4.7075529", "23.1.7779620", "22.0.7026061"} {
+		stageNDK(t, ndkRoot, ndkVersion)
+	}
+
+	sdk, err := New(tmpDir)
+	require.NoError(t, err)
+
+	latestNDKDir, err := sdk.LatestNDKDir()
+	require.NoError(t, err)
+	require.Equal(t, true, strings.Contains(latestNDKDir, filepath.Join("ndk", "23.1.7779620")), latestNDKDir)
+}
+
+func TestNoNDKDir(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	sdk, err := New(tmpDir)
+	require.NoError(t, err)
+
+	_, err = sdk.LatestNDKDir()
+	require.Error(t, err)
+}
+
+func TestNDKRoot_explicitOverride(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	explicitRoot := filepath.Join(tmpDir, "custom-ndk-root")
+	require.NoError(t, os.MkdirAll(explicitRoot, 0700))
+
+	unsetNDKRoot, err := envutil.RevokableSetenv("ANDROID_NDK_ROOT", explicitRoot)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, unsetNDKRoot()) }()
+
+	sdk, err := New(tmpDir)
+	require.NoError(t, err)
+
+	ndkRoot, err := sdk.NDKRoot()
+	require.NoError(t, err)
+	require.Equal(t, explicitRoot, ndkRoot)
+}
+
+func TestNDKRevision(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	ndkRoot := filepath.Join(tmpDir, "ndk")
+	stageNDK(t, ndkRoot, "23.1.7779620")
+
+	sdk, err := New(tmpDir)
+	require.NoError(t, err)
+
+	revision, err := sdk.NDKRevision()
+	require.NoError(t, err)
+	require.Equal(t, "23.1.7779620", revision)
+}
+
+func TestNDKSupportedABIs(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	ndkRoot := filepath.Join(tmpDir, "ndk")
+	stageNDK(t, ndkRoot, "23.1.7779620")
+
+	sdk, err := New(tmpDir)
+	require.NoError(t, err)
+
+	abis, err := sdk.NDKSupportedABIs()
+	require.NoError(t, err)
+	sort.Strings(abis)
+	require.Equal(t, []string{"arm64-v8a", "armeabi-v7a"}, abis)
+}
+
+func TestNDKPlatformRange(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	ndkRoot := filepath.Join(tmpDir, "ndk")
+	stageNDK(t, ndkRoot, "23.1.7779620")
+
+	sdk, err := New(tmpDir)
+	require.NoError(t, err)
+
+	platformRange, err := sdk.NDKPlatformRange()
+	require.NoError(t, err)
+	require.Equal(t, 16, platformRange.Min)
+	require.Equal(t, 33, platformRange.Max)
+	require.Equal(t, 19, platformRange.Aliases["20"])
+}
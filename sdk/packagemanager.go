@@ -0,0 +1,201 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+// Package describes a single entry reported by `sdkmanager --list`.
+type Package struct {
+	Path        string
+	Version     string
+	Description string
+	Installed   bool
+}
+
+// Logger is the subset of logging behavior PackageManager needs to stream
+// sdkmanager's stdout/stderr through.
+type Logger interface {
+	Println(args ...interface{})
+}
+
+// PackageManager wraps the sdkmanager command-line tool, letting callers
+// ensure packages are installed without shelling out themselves.
+type PackageManager struct {
+	sdkmanagerPath string
+	logger         Logger
+}
+
+// PackageManagerOption configures a PackageManager returned by Model.PackageManager.
+type PackageManagerOption func(*PackageManager)
+
+// WithLogger overrides the logger sdkmanager's stdout/stderr is streamed through.
+func WithLogger(logger Logger) PackageManagerOption {
+	return func(pm *PackageManager) {
+		pm.logger = logger
+	}
+}
+
+// PackageManager locates the sdkmanager binary via CmdlineToolsPath, falling
+// back to the legacy tools/bin/sdkmanager, and wraps it.
+func (model Model) PackageManager(opts ...PackageManagerOption) (*PackageManager, error) {
+	sdkmanagerPath, err := model.sdkmanagerPath()
+	if err != nil {
+		return nil, err
+	}
+
+	pm := &PackageManager{
+		sdkmanagerPath: sdkmanagerPath,
+		logger:         log.New(os.Stdout, "", 0),
+	}
+	for _, opt := range opts {
+		opt(pm)
+	}
+
+	return pm, nil
+}
+
+func (model Model) sdkmanagerPath() (string, error) {
+	if cmdlineToolsPath, err := model.CmdlineToolsPath(); err == nil {
+		candidate := filepath.Join(cmdlineToolsPath, "sdkmanager")
+		if exist, err := pathutil.IsPathExists(candidate); err == nil && exist {
+			return candidate, nil
+		}
+	}
+
+	legacyPath := filepath.Join(model.androidHome, "tools", "bin", "sdkmanager")
+	if exist, err := pathutil.IsPathExists(legacyPath); err == nil && exist {
+		return legacyPath, nil
+	}
+
+	return "", fmt.Errorf("sdkmanager not found under: %s", model.androidHome)
+}
+
+// List runs `sdkmanager --list` and parses its tab-separated output into
+// installed and available Packages.
+func (pm *PackageManager) List(ctx context.Context) ([]Package, error) {
+	out, err := pm.run(exec.CommandContext(ctx, pm.sdkmanagerPath, "--list"))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSdkmanagerList(out), nil
+}
+
+// Install runs `sdkmanager <packages...>`, installing any of the given
+// packages that are missing.
+func (pm *PackageManager) Install(ctx context.Context, packages ...string) error {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	_, err := pm.run(exec.CommandContext(ctx, pm.sdkmanagerPath, packages...))
+	return err
+}
+
+// Update runs `sdkmanager --update`, updating all installed packages.
+func (pm *PackageManager) Update(ctx context.Context) error {
+	_, err := pm.run(exec.CommandContext(ctx, pm.sdkmanagerPath, "--update"))
+	return err
+}
+
+// AcceptLicenses runs `sdkmanager --licenses`, answering "y" to every prompt.
+func (pm *PackageManager) AcceptLicenses(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, pm.sdkmanagerPath, "--licenses")
+	cmd.Stdin = yesReader{}
+
+	_, err := pm.run(cmd)
+	return err
+}
+
+// yesReader endlessly answers "y\n", the same way the Unix `yes` command
+// does, so AcceptLicenses never runs out of answers regardless of how many
+// license prompts sdkmanager has.
+type yesReader struct{}
+
+func (yesReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = "y\n"[i%2]
+	}
+
+	return len(p), nil
+}
+
+func (pm *PackageManager) run(cmd *exec.Cmd) (string, error) {
+	var stdout bytes.Buffer
+	cmd.Stdout = io.MultiWriter(&stdout, loggerWriter{pm.logger})
+	cmd.Stderr = loggerWriter{pm.logger}
+
+	if err := cmd.Run(); err != nil {
+		return stdout.String(), fmt.Errorf("sdkmanager %s failed: %w", strings.Join(cmd.Args[1:], " "), err)
+	}
+
+	return stdout.String(), nil
+}
+
+// loggerWriter adapts a Logger to an io.Writer, line by line.
+type loggerWriter struct {
+	logger Logger
+}
+
+func (w loggerWriter) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if line != "" {
+			w.logger.Println(line)
+		}
+	}
+
+	return len(p), nil
+}
+
+func parseSdkmanagerList(output string) []Package {
+	var packages []Package
+	installed := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.EqualFold(trimmed, "Installed packages:"):
+			installed = true
+			continue
+		case strings.EqualFold(trimmed, "Available Packages:"):
+			installed = false
+			continue
+		}
+
+		if !strings.Contains(line, "\t") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		if len(fields) < 3 || fields[0] == "" || fields[0] == "Path" {
+			continue
+		}
+
+		packages = append(packages, Package{
+			Path:        fields[0],
+			Version:     fields[1],
+			Description: fields[2],
+			Installed:   installed,
+		})
+	}
+
+	return packages
+}
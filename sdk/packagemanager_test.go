@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Println(args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprint(args...))
+}
+
+func stageSdkmanagerFixture(t *testing.T, sdkRoot, script string) {
+	binDir := filepath.Join(sdkRoot, "cmdline-tools", "latest", "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0700))
+
+	sdkmanagerPath := filepath.Join(binDir, "sdkmanager")
+	require.NoError(t, fileutil.WriteStringToFile(sdkmanagerPath, script))
+	require.NoError(t, os.Chmod(sdkmanagerPath, 0755))
+}
+
+func TestPackageManager_List(t *testing.T) {
+	sdkRoot, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	script := "#!/bin/sh\n" +
+		"if [ \"$1\" = \"--list\" ]; then\n" +
+		"  echo 'Installed packages:'\n" +
+		"  printf 'platform-tools\\t33.0.3\\tAndroid SDK Platform-Tools\\n'\n" +
+		"  echo 'Available Packages:'\n" +
+		"  printf 'build-tools;30.0.3\\t30.0.3\\tAndroid SDK Build-Tools 30.0.3\\n'\n" +
+		"fi\n"
+	stageSdkmanagerFixture(t, sdkRoot, script)
+
+	model := &Model{androidHome: sdkRoot}
+	logger := &testLogger{}
+	pm, err := model.PackageManager(WithLogger(logger))
+	require.NoError(t, err)
+
+	packages, err := pm.List(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{Path: "platform-tools", Version: "33.0.3", Description: "Android SDK Platform-Tools", Installed: true},
+		{Path: "build-tools;30.0.3", Version: "30.0.3", Description: "Android SDK Build-Tools 30.0.3", Installed: false},
+	}, packages)
+	require.NotEmpty(t, logger.lines)
+}
+
+func TestPackageManager_Install(t *testing.T) {
+	sdkRoot, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	stageSdkmanagerFixture(t, sdkRoot, "#!/bin/sh\nexit 0\n")
+
+	model := &Model{androidHome: sdkRoot}
+	pm, err := model.PackageManager()
+	require.NoError(t, err)
+
+	require.NoError(t, pm.Install(context.Background(), "platforms;android-33"))
+}
+
+func TestPackageManager_AcceptLicenses(t *testing.T) {
+	sdkRoot, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	// yesReader never reaches EOF, so the fixture reads a single answer
+	// (as a license prompt would) instead of draining stdin.
+	stageSdkmanagerFixture(t, sdkRoot, "#!/bin/sh\nread answer\n[ \"$answer\" = y ]\n")
+
+	model := &Model{androidHome: sdkRoot}
+	pm, err := model.PackageManager()
+	require.NoError(t, err)
+
+	require.NoError(t, pm.AcceptLicenses(context.Background()))
+}
+
+func TestPackageManager_NotFound(t *testing.T) {
+	sdkRoot, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	model := &Model{androidHome: sdkRoot}
+	_, err = model.PackageManager()
+	require.Error(t, err)
+}
@@ -0,0 +1,259 @@
+// Package sdk implements Android SDK related model and helper functions.
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	version "github.com/hashicorp/go-version"
+)
+
+// Model ...
+type Model struct {
+	androidHome string
+}
+
+// Environment holds the environment variables and host details used to locate
+// an installed Android SDK.
+type Environment struct {
+	androidHome    string
+	androidSDKRoot string
+	homeDir        string
+	localAppData   string
+	goos           string
+	pathDirs       []string
+}
+
+// NewEnvironment reads the ANDROID_HOME and ANDROID_SDK_ROOT environment
+// variables, along with the host details (home directory, PATH, OS) used to
+// probe for an SDK install when neither is set.
+func NewEnvironment() *Environment {
+	homeDir, _ := os.UserHomeDir()
+
+	return &Environment{
+		androidHome:    os.Getenv("ANDROID_HOME"),
+		androidSDKRoot: os.Getenv("ANDROID_SDK_ROOT"),
+		homeDir:        homeDir,
+		localAppData:   os.Getenv("LOCALAPPDATA"),
+		goos:           currentGOOS(),
+		pathDirs:       currentPathDirs(),
+	}
+}
+
+// New creates a new Model, rooted at androidHome.
+func New(androidHome string) (*Model, error) {
+	absAndroidHome, err := filepath.Abs(androidHome)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Model{androidHome: absAndroidHome}, nil
+}
+
+// NewDefaultModel creates a new Model, picking up the SDK root from the given
+// Environment via DiscoverSDK: ANDROID_HOME takes precedence over
+// ANDROID_SDK_ROOT, falling back to probing well-known install locations.
+func NewDefaultModel(env Environment) (*Model, error) {
+	androidHome, _, err := DiscoverSDK(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(androidHome)
+}
+
+// AndroidHome ...
+func (model Model) AndroidHome() string {
+	return model.androidHome
+}
+
+// LatestBuildToolsDir locates the build-tools directory with the highest version number.
+func (model Model) LatestBuildToolsDir() (string, error) {
+	pattern := filepath.Join(model.androidHome, "build-tools", "*")
+	buildToolsDirs, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var latestDir string
+	var latestVersion *version.Version
+	for _, dir := range buildToolsDirs {
+		v, err := version.NewVersion(filepath.Base(dir))
+		if err != nil {
+			continue
+		}
+
+		if latestVersion == nil || v.GreaterThan(latestVersion) {
+			latestVersion = v
+			latestDir = dir
+		}
+	}
+
+	if latestDir == "" {
+		return "", errors.New("failed to find latest build-tools dir")
+	}
+
+	return latestDir, nil
+}
+
+// LatestBuildToolPath returns the path of tool inside the latest build-tools directory.
+func (model Model) LatestBuildToolPath(tool string) (string, error) {
+	buildToolsDir, err := model.LatestBuildToolsDir()
+	if err != nil {
+		return "", err
+	}
+
+	toolPath := filepath.Join(buildToolsDir, tool)
+	if exist, err := pathutil.IsPathExists(toolPath); err != nil {
+		return "", err
+	} else if !exist {
+		return "", fmt.Errorf("tool (%s) not found at: %s", tool, toolPath)
+	}
+
+	return toolPath, nil
+}
+
+// BuildToolsDirsMatching returns every build-tools directory whose version
+// satisfies constraint (a github.com/hashicorp/go-version constraint string,
+// e.g. ">= 30.0.0"), ordered from lowest to highest version.
+func (model Model) BuildToolsDirsMatching(constraint string) ([]string, error) {
+	versionConstraint, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := filepath.Join(model.androidHome, "build-tools", "*")
+	buildToolsDirs, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	type versionedDir struct {
+		dir string
+		v   *version.Version
+	}
+
+	var matching []versionedDir
+	for _, dir := range buildToolsDirs {
+		v, err := version.NewVersion(filepath.Base(dir))
+		if err != nil {
+			continue
+		}
+
+		if versionConstraint.Check(v) {
+			matching = append(matching, versionedDir{dir: dir, v: v})
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].v.LessThan(matching[j].v)
+	})
+
+	if len(matching) == 0 {
+		return nil, fmt.Errorf("failed to find build-tools dir matching constraint: %s", constraint)
+	}
+
+	dirs := make([]string, len(matching))
+	for i, m := range matching {
+		dirs[i] = m.dir
+	}
+
+	return dirs, nil
+}
+
+// LatestBuildToolPathWithConstraint returns the path of tool inside the
+// highest-versioned build-tools directory whose version is >= min and,
+// if max is non-empty, <= max.
+func (model Model) LatestBuildToolPathWithConstraint(tool, min, max string) (string, error) {
+	constraint := buildToolsConstraint(min, max)
+
+	dirs, err := model.BuildToolsDirsMatching(constraint)
+	if err != nil {
+		return "", fmt.Errorf("failed to find latest build-tools dir: %w", err)
+	}
+
+	latestDir := dirs[len(dirs)-1]
+	toolPath := filepath.Join(latestDir, tool)
+	if exist, err := pathutil.IsPathExists(toolPath); err != nil {
+		return "", err
+	} else if !exist {
+		return "", fmt.Errorf("tool (%s) not found at: %s", tool, toolPath)
+	}
+
+	return toolPath, nil
+}
+
+func buildToolsConstraint(min, max string) string {
+	var parts []string
+	if min != "" {
+		parts = append(parts, fmt.Sprintf(">= %s", min))
+	}
+	if max != "" {
+		parts = append(parts, fmt.Sprintf("<= %s", max))
+	}
+
+	if len(parts) == 0 {
+		return ">= 0"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// CmdlineToolsPath locates the `bin` directory of the command-line tools,
+// preferring `cmdline-tools/latest/bin`, falling back to the highest versioned
+// `cmdline-tools/<version>/bin`, and finally the legacy `tools/bin`.
+func (model Model) CmdlineToolsPath() (string, error) {
+	cmdlineToolsDir := filepath.Join(model.androidHome, "cmdline-tools")
+	if exist, err := pathutil.IsDirExists(cmdlineToolsDir); err != nil {
+		return "", err
+	} else if exist {
+		latestPath := filepath.Join(cmdlineToolsDir, "latest", "bin")
+		if exist, err := pathutil.IsDirExists(latestPath); err != nil {
+			return "", err
+		} else if exist {
+			return latestPath, nil
+		}
+
+		entries, err := ioutil.ReadDir(cmdlineToolsDir)
+		if err != nil {
+			return "", err
+		}
+
+		var latestVersionName string
+		var latestVersion *version.Version
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+
+			v, err := version.NewVersion(entry.Name())
+			if err != nil {
+				continue
+			}
+
+			if latestVersion == nil || v.GreaterThan(latestVersion) {
+				latestVersion = v
+				latestVersionName = entry.Name()
+			}
+		}
+
+		if latestVersionName != "" {
+			return filepath.Join(cmdlineToolsDir, latestVersionName, "bin"), nil
+		}
+	}
+
+	toolsBinPath := filepath.Join(model.androidHome, "tools", "bin")
+	if exist, err := pathutil.IsDirExists(toolsBinPath); err != nil {
+		return "", err
+	} else if exist {
+		return toolsBinPath, nil
+	}
+
+	return "", errors.New("failed to find cmdline-tools or tools/bin directory")
+}
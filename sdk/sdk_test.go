@@ -43,6 +43,74 @@ func TestNoBuildToolsDir(t *testing.T) {
 	require.EqualError(t, err, "failed to find latest build-tools dir")
 }
 
+func TestBuildToolsDirsMatching(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	buildToolsVersions := []string{"22.0.4", "25.0.2", "25.0.3", "30.0.3"}
+	for _, buildToolsVersion := range buildToolsVersions {
+		buildToolsVersionPth := filepath.Join(tmpDir, "build-tools", buildToolsVersion)
+		require.NoError(t, os.MkdirAll(buildToolsVersionPth, 0700))
+	}
+
+	sdk, err := New(tmpDir)
+	require.NoError(t, err)
+
+	t.Log(">= 25.0.0 - matches 25.0.2, 25.0.3, 30.0.3, ordered ascending")
+	{
+		dirs, err := sdk.BuildToolsDirsMatching(">= 25.0.0")
+		require.NoError(t, err)
+		require.Equal(t, 3, len(dirs))
+		require.Equal(t, true, strings.Contains(dirs[0], filepath.Join("build-tools", "25.0.2")), dirs[0])
+		require.Equal(t, true, strings.Contains(dirs[1], filepath.Join("build-tools", "25.0.3")), dirs[1])
+		require.Equal(t, true, strings.Contains(dirs[2], filepath.Join("build-tools", "30.0.3")), dirs[2])
+	}
+
+	t.Log(">= 25.0.0, <= 25.0.3 - matches 25.0.2, 25.0.3 only")
+	{
+		dirs, err := sdk.BuildToolsDirsMatching(">= 25.0.0, <= 25.0.3")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(dirs))
+		require.Equal(t, true, strings.Contains(dirs[1], filepath.Join("build-tools", "25.0.3")), dirs[1])
+	}
+
+	t.Log(">= 31.0.0 - matches nothing")
+	{
+		_, err := sdk.BuildToolsDirsMatching(">= 31.0.0")
+		require.EqualError(t, err, "failed to find build-tools dir matching constraint: >= 31.0.0")
+	}
+}
+
+func TestLatestBuildToolPathWithConstraint(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
+	require.NoError(t, err)
+
+	buildToolsVersions := []string{"22.0.4", "25.0.2", "25.0.3", "30.0.3"}
+	for _, buildToolsVersion := range buildToolsVersions {
+		buildToolsVersionPth := filepath.Join(tmpDir, "build-tools", buildToolsVersion)
+		require.NoError(t, os.MkdirAll(buildToolsVersionPth, 0700))
+	}
+
+	zipalignPth := filepath.Join(tmpDir, "build-tools", "25.0.3", "zipalign")
+	require.NoError(t, fileutil.WriteStringToFile(zipalignPth, ""))
+
+	sdk, err := New(tmpDir)
+	require.NoError(t, err)
+
+	t.Log("min 25.0.0, max 25.0.3 - zipalign resolved from 25.0.3")
+	{
+		pth, err := sdk.LatestBuildToolPathWithConstraint("zipalign", "25.0.0", "25.0.3")
+		require.NoError(t, err)
+		require.Equal(t, true, strings.Contains(pth, filepath.Join("build-tools", "25.0.3", "zipalign")), pth)
+	}
+
+	t.Log("min above every staged version - error")
+	{
+		_, err := sdk.LatestBuildToolPathWithConstraint("zipalign", "31.0.0", "")
+		require.Error(t, err)
+	}
+}
+
 func TestLatestBuildToolPath(t *testing.T) {
 	tmpDir, err := pathutil.NormalizedOSTempDirPath("")
 	require.NoError(t, err)
@@ -106,6 +174,7 @@ func TestNewDefaultModel(t *testing.T) {
 	tests := []struct {
 		name    string
 		envs    map[string]string
+		env     *Environment
 		want    *Model
 		wantErr bool
 	}{
@@ -140,11 +209,21 @@ func TestNewDefaultModel(t *testing.T) {
 			},
 		},
 		{
+			// Uses a synthetic Environment rather than NewEnvironment(), since
+			// NewDefaultModel now falls back to DiscoverSDK's host probing
+			// (home directory, PATH, LOCALAPPDATA) whenever both env vars are
+			// empty: going through the real environment would make this test's
+			// outcome depend on whatever SDK/tooling happens to be installed
+			// on the machine running it.
 			name: "neither ANDROID_HOME, ANDROID_SDK_ROOT set",
 			envs: map[string]string{
 				"ANDROID_HOME":     "",
 				"ANDROID_SDK_ROOT": "",
 			},
+			env: &Environment{
+				goos:    "linux",
+				homeDir: filepath.Join(androidHome, "no-such-home"),
+			},
 			want:    nil,
 			wantErr: true,
 		},
@@ -161,7 +240,12 @@ func TestNewDefaultModel(t *testing.T) {
 				unsetEnvs = append(unsetEnvs, unsetEnv)
 			}
 
-			got, err := NewDefaultModel(*NewEnvironment())
+			env := tt.env
+			if env == nil {
+				env = NewEnvironment()
+			}
+
+			got, err := NewDefaultModel(*env)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewDefaultModel() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -208,6 +292,16 @@ func TestModel_CmdlineToolsPath(t *testing.T) {
 			},
 			wantPath: "cmdline-tools/1.0/bin",
 		},
+		{
+			// 10.0 sorts before 9.0 lexicographically, so this pins down
+			// semver-aware (not string) version comparison.
+			name: "Command-line tools, numeric versions requiring semver comparison",
+			SDKlayout: []string{
+				"cmdline-tools/9.0/bin",
+				"cmdline-tools/10.0/bin",
+			},
+			wantPath: "cmdline-tools/10.0/bin",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {